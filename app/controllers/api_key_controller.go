@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koddr/tutorial-go-fiber-rest-api/app/models"
+	"github.com/koddr/tutorial-go-fiber-rest-api/app/validators"
+	"github.com/koddr/tutorial-go-fiber-rest-api/pkg/utils"
+	"github.com/koddr/tutorial-go-fiber-rest-api/platform/database"
+	"github.com/lib/pq"
+)
+
+// ListApiKeys func lists the API keys owned by the authenticated account.
+// @Description List the API keys owned by the authenticated account.
+// @Summary list owned API keys
+// @Tags Private
+// @Accept json
+// @Produce json
+// @Success 200 {array} models.ApiKey
+// @Router /api/v1/keys [get]
+func ListApiKeys(c *fiber.Ctx) error {
+	// Get claims from JWT or API key.
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 500 and token parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		// Return status 500 and database connection error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Get API keys owned by the authenticated account.
+	keys, err := db.GetApiKeysByOwner(claims.Subject)
+	if err != nil {
+		// Return, if keys not found.
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": true,
+			"msg":   "api keys were not found",
+			"count": 0,
+			"keys":  nil,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+		"count": len(keys),
+		"keys":  keys,
+	})
+}
+
+// CreateApiKey func issues a new API key for the authenticated account. The plaintext secret
+// is returned exactly once, in this response; only its salted hash is ever stored.
+// @Description Issue a new API key for the authenticated account.
+// @Summary create an API key
+// @Tags Private
+// @Accept json
+// @Produce json
+// @Param name body string true "Name"
+// @Param scopes body []string true "Scopes"
+// @Success 201 {object} models.ApiKey
+// @Router /api/v1/keys [post]
+func CreateApiKey(c *fiber.Ctx) error {
+	// Get claims from JWT or API key.
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 500 and token parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Create a new input struct for the requested key.
+	input := &struct {
+		Name      string     `json:"name"`
+		Scopes    []string   `json:"scopes"`
+		ExpiresAt *time.Time `json:"expires_at"`
+	}{}
+
+	// Checking received data from JSON body.
+	if err := c.BodyParser(input); err != nil {
+		// Return, if JSON data is not correct.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// A minted key must never grant more than the caller's own credentials, otherwise an
+	// account could escalate its privileges by requesting scopes it doesn't itself hold.
+	for _, scope := range input.Scopes {
+		if !claims.Credentials[scope] {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": true,
+				"msg":   "permission denied, cannot grant scope not held by the caller: " + scope,
+			})
+		}
+	}
+
+	// Generate the plaintext secret and its salted hash.
+	plaintext, id, hashedSecret, err := utils.GenerateAPIKeySecret()
+	if err != nil {
+		// Return status 500 and key generation error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	key := &models.ApiKey{
+		ID:           id,
+		OwnerSub:     claims.Subject,
+		Name:         input.Name,
+		HashedSecret: hashedSecret,
+		Scopes:       pq.StringArray(input.Scopes),
+		ExpiresAt:    input.ExpiresAt,
+		CreatedAt:    time.Now(),
+	}
+
+	// Create a new validator for an API key model.
+	validate := validators.ApiKeyValidator()
+
+	// Validate API key fields.
+	if err := validate.Struct(key); err != nil {
+		// Return, if some fields are not valid.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   utils.ValidatorErrors(err),
+		})
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		// Return status 500 and database connection error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Create a new API key.
+	if err := db.CreateApiKey(key); err != nil {
+		// Return status 500 and create key process error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":  false,
+		"msg":    nil,
+		"key":    key,
+		"secret": plaintext,
+	})
+}
+
+// RevokeApiKey func revokes an API key owned by the authenticated account.
+// @Description Revoke an API key owned by the authenticated account.
+// @Summary revoke an API key
+// @Tags Private
+// @Accept json
+// @Produce json
+// @Param id path string true "API Key ID"
+// @Success 200 {string} string "ok"
+// @Router /api/v1/keys/{id} [delete]
+func RevokeApiKey(c *fiber.Ctx) error {
+	// Get claims from JWT or API key.
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 500 and token parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Catch API key ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		// Return status 500 and database connection error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Checking, if API key with given ID is exists, owned by the caller, and not already revoked.
+	key, err := db.GetApiKeyByID(id)
+	if err != nil || key.OwnerSub != claims.Subject || key.RevokedAt != nil {
+		// Return status 404 and key not found error.
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": true,
+			"msg":   "api key not found",
+		})
+	}
+
+	// Revoke the API key, scoped to the authenticated owner.
+	if err := db.RevokeApiKey(id, claims.Subject); err != nil {
+		// Return status 500 and revoke key process error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+	})
+}
@@ -1,6 +1,11 @@
 package controllers
 
 import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -8,9 +13,88 @@ import (
 	"github.com/koddr/tutorial-go-fiber-rest-api/app/models"
 	"github.com/koddr/tutorial-go-fiber-rest-api/app/validators"
 	"github.com/koddr/tutorial-go-fiber-rest-api/pkg/utils"
+	"github.com/koddr/tutorial-go-fiber-rest-api/platform/cache"
 	"github.com/koddr/tutorial-go-fiber-rest-api/platform/database"
+	"github.com/koddr/tutorial-go-fiber-rest-api/platform/events"
 )
 
+// recordBookActionEvent func writes an audit row for a book mutation; failures are logged and
+// never fail the HTTP response.
+func recordBookActionEvent(c *fiber.Ctx, db *database.Queries, action string, bookID uuid.UUID, actor string) {
+	event := &models.ActionEvent{
+		ID:         uuid.New(),
+		AccountSub: actor,
+		Action:     action,
+		TargetType: "book",
+		TargetID:   bookID,
+		IP:         c.IP(),
+		UserAgent:  string(c.Request().Header.UserAgent()),
+		CreatedAt:  time.Now(),
+	}
+
+	if err := db.CreateActionEvent(event); err != nil {
+		log.Printf("events: failed to record audit log for book %s action: %v", action, err)
+	}
+}
+
+// booksAllCacheKey is the cache key for the full book collection.
+const booksAllCacheKey = "books:all"
+
+// bookCacheKey builds the cache key for a single book by ID.
+func bookCacheKey(id uuid.UUID) string {
+	return fmt.Sprintf("book:%s", id)
+}
+
+// booksLastEdit tracks the last time any book was created, updated, or deleted, guarded by
+// booksLastEditMu so GetBooks can answer conditional requests without a database round trip.
+var (
+	booksLastEdit   = time.Now()
+	booksLastEditMu sync.RWMutex
+)
+
+// touchBooksLastEdit bumps booksLastEdit to now; called after every successful book mutation.
+func touchBooksLastEdit() {
+	booksLastEditMu.Lock()
+	booksLastEdit = time.Now()
+	booksLastEditMu.Unlock()
+}
+
+// currentBooksLastEdit returns the last recorded mutation time for the book collection.
+func currentBooksLastEdit() time.Time {
+	booksLastEditMu.RLock()
+	defer booksLastEditMu.RUnlock()
+	return booksLastEdit
+}
+
+// bookETag builds a strong ETag from a point in time.
+func bookETag(t time.Time) string {
+	return fmt.Sprintf(`"%x"`, sha1.Sum([]byte(t.UTC().Format(time.RFC3339Nano))))
+}
+
+// publishBookEvent func publishes a book mutation event, forwarding the X-Request-Source
+// header so subscribers can suppress echo-back. Publishing failures are logged and never
+// fail the HTTP response.
+func publishBookEvent(c *fiber.Ctx, action string, book *models.Book, actor string) {
+	publisher, err := events.NewPublisher()
+	if err != nil {
+		log.Printf("events: failed to obtain publisher for book %s event: %v", action, err)
+		return
+	}
+
+	event := events.Event{
+		Object:        "book",
+		Action:        action,
+		Data:          book,
+		Actor:         actor,
+		RequestSource: c.Get("X-Request-Source"),
+		Timestamp:     time.Now(),
+	}
+
+	if err := publisher.Publish(event); err != nil {
+		log.Printf("events: failed to publish book %s event: %v", action, err)
+	}
+}
+
 // GetBooks func gets all exists books.
 // @Description Get all exists books.
 // @Summary get all exists books
@@ -20,6 +104,35 @@ import (
 // @Success 200 {array} models.Book
 // @Router /api/v1/books [get]
 func GetBooks(c *fiber.Ctx) error {
+	take := c.Query("take")
+	offset := c.Query("offset")
+	sort := c.Query("sort")
+	author := c.Query("author")
+	status := c.Query("status")
+	q := c.Query("q")
+
+	// Fall through to the paged, filtered listing as soon as any of those query params is
+	// supplied, so the plain `GET /books` response stays exactly as it was before.
+	if take != "" || offset != "" || sort != "" || author != "" || status != "" || q != "" {
+		return getBooksPaged(c, take, offset, sort, author, status, q)
+	}
+
+	// Answer conditional requests without touching Redis or the database at all.
+	lastEdit := currentBooksLastEdit()
+	if utils.ConditionalCache(c, lastEdit, bookETag(lastEdit)) {
+		return nil
+	}
+
+	// Try to serve the collection from Redis first, falling back to the database
+	// when the cache is empty or unreachable.
+	rdb, rdbErr := cache.OpenRedisConnection()
+	if rdbErr == nil {
+		if cached, ok := cache.GetCache(rdb, booksAllCacheKey); ok {
+			c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return c.Send(cached)
+		}
+	}
+
 	// Create database connection.
 	db, err := database.OpenDBConnection()
 	if err != nil {
@@ -42,10 +155,64 @@ func GetBooks(c *fiber.Ctx) error {
 		})
 	}
 
+	result := fiber.Map{
+		"error": false,
+		"msg":   nil,
+		"count": len(books),
+		"books": books,
+	}
+
+	// Populate the cache for the next read, ignoring marshal/Redis errors.
+	if rdbErr == nil {
+		if payload, err := json.Marshal(result); err == nil {
+			cache.SetCache(rdb, booksAllCacheKey, payload, cache.BooksCacheTTL())
+		}
+	}
+
+	return c.JSON(result)
+}
+
+// getBooksPaged handles `GET /books` once any of take, offset, sort, author, status, or q is
+// supplied, returning `{count, total, books}` where total is the unfiltered-by-page match
+// count.
+func getBooksPaged(c *fiber.Ctx, take, offset, sort, author, status, q string) error {
+	opts, err := database.NewBookListOptions(take, offset, sort, author, status, q)
+	if err != nil {
+		// Return status 400 and the invalid query parameter error.
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		// Return status 500 and database connection error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Get the matching page of books plus the unfiltered-by-page total.
+	books, total, err := db.GetBooksPaged(opts)
+	if err != nil {
+		// Return, if books not found.
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": true,
+			"msg":   "books were not found",
+			"count": 0,
+			"total": 0,
+			"books": nil,
+		})
+	}
+
 	return c.JSON(fiber.Map{
 		"error": false,
 		"msg":   nil,
 		"count": len(books),
+		"total": total,
 		"books": books,
 	})
 }
@@ -69,32 +236,97 @@ func GetBook(c *fiber.Ctx) error {
 		})
 	}
 
-	// Create database connection.
-	db, err := database.OpenDBConnection()
-	if err != nil {
-		// Return status 500 and database connection error.
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": true,
-			"msg":   err.Error(),
-		})
+	// Try to serve the book from Redis first, falling back to the database when the
+	// cache is empty or unreachable.
+	var book *models.Book
+
+	rdb, rdbErr := cache.OpenRedisConnection()
+	if rdbErr == nil {
+		if cached, ok := cache.GetCache(rdb, bookCacheKey(id)); ok {
+			book = &models.Book{}
+			if err := json.Unmarshal(cached, book); err != nil {
+				book = nil
+			}
+		}
 	}
 
-	// Get book by ID.
-	book, err := db.GetBook(id)
-	if err != nil {
-		// Return, if book not found.
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": true,
-			"msg":   "book with the given ID is not found",
-			"book":  nil,
-		})
+	if book == nil {
+		// Create database connection.
+		db, err := database.OpenDBConnection()
+		if err != nil {
+			// Return status 500 and database connection error.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+
+		// Get book by ID.
+		book, err = db.GetBook(id)
+		if err != nil {
+			// Return, if book not found.
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": true,
+				"msg":   "book with the given ID is not found",
+				"book":  nil,
+			})
+		}
+
+		// Populate the cache for the next read, ignoring marshal/Redis errors.
+		if rdbErr == nil {
+			if payload, err := json.Marshal(book); err == nil {
+				cache.SetCache(rdb, bookCacheKey(id), payload, cache.BooksCacheTTL())
+			}
+		}
 	}
 
-	return c.JSON(fiber.Map{
+	// Answer conditional requests before serializing the full payload.
+	lastEdit := book.UpdatedAt
+	if lastEdit.IsZero() {
+		lastEdit = book.CreatedAt
+	}
+	if utils.ConditionalCache(c, lastEdit, bookETag(lastEdit)) {
+		return nil
+	}
+
+	result := fiber.Map{
 		"error": false,
 		"msg":   nil,
 		"book":  book,
-	})
+	}
+
+	// Expand the collections this book belongs to, when asked for.
+	if c.Query("expand") == "collections" {
+		db, err := database.OpenDBConnection()
+		if err != nil {
+			// Return status 500 and database connection error.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+
+		collections, err := db.GetBookCollections(book.ID)
+		if err != nil {
+			// Return status 500 and expand collections error.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+
+		// A private collection is only visible to its owner, same as GetCollection.
+		visible := make([]*models.Collection, 0, len(collections))
+		for _, collection := range collections {
+			if collection.IsPublic || isCollectionOwner(c, collection) {
+				visible = append(visible, collection)
+			}
+		}
+
+		result["collections"] = visible
+	}
+
+	return c.JSON(result)
 }
 
 // CreateBook func for creates a new book.
@@ -178,6 +410,16 @@ func CreateBook(c *fiber.Ctx) error {
 				"msg":   err.Error(),
 			})
 		}
+
+		// Invalidate the cached collection now that a new book exists.
+		if rdb, err := cache.OpenRedisConnection(); err == nil {
+			cache.InvalidateCache(rdb, booksAllCacheKey)
+		}
+		touchBooksLastEdit()
+
+		// Notify subscribers of the new book.
+		publishBookEvent(c, "create", book, claims.Subject)
+		recordBookActionEvent(c, db, "create", book.ID, claims.Subject)
 	} else {
 		// Return status 403 and permission denied error.
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -279,6 +521,16 @@ func UpdateBook(c *fiber.Ctx) error {
 				"msg":   err.Error(),
 			})
 		}
+
+		// Invalidate the cached collection and the affected book atomically.
+		if rdb, err := cache.OpenRedisConnection(); err == nil {
+			cache.InvalidateCache(rdb, booksAllCacheKey, bookCacheKey(book.ID))
+		}
+		touchBooksLastEdit()
+
+		// Notify subscribers of the update.
+		publishBookEvent(c, "update", book, claims.Subject)
+		recordBookActionEvent(c, db, "update", book.ID, claims.Subject)
 	} else {
 		// Return status 403 and permission denied error.
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -365,6 +617,16 @@ func DeleteBook(c *fiber.Ctx) error {
 				"msg":   err.Error(),
 			})
 		}
+
+		// Invalidate the cached collection and the affected book atomically.
+		if rdb, err := cache.OpenRedisConnection(); err == nil {
+			cache.InvalidateCache(rdb, booksAllCacheKey, bookCacheKey(book.ID))
+		}
+		touchBooksLastEdit()
+
+		// Notify subscribers of the deletion.
+		publishBookEvent(c, "delete", book, claims.Subject)
+		recordBookActionEvent(c, db, "delete", book.ID, claims.Subject)
 	} else {
 		// Return status 403 and permission denied error.
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
@@ -0,0 +1,637 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/koddr/tutorial-go-fiber-rest-api/app/models"
+	"github.com/koddr/tutorial-go-fiber-rest-api/app/validators"
+	"github.com/koddr/tutorial-go-fiber-rest-api/pkg/utils"
+	"github.com/koddr/tutorial-go-fiber-rest-api/platform/database"
+)
+
+// isCollectionOwner reports whether the caller's token (JWT or API key), if any, belongs to
+// collection's owner. A missing or invalid token is treated as anonymous, not an error, since
+// this is used to gate read access to an otherwise-public endpoint.
+func isCollectionOwner(c *fiber.Ctx, collection *models.Collection) bool {
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		return false
+	}
+
+	return claims.Subject == collection.OwnerSub
+}
+
+// GetCollection func gets collection by given ID or 404 error.
+// @Description Get collection by given ID.
+// @Summary get collection by given ID
+// @Tags Public
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {object} models.Collection
+// @Router /api/v1/collection/{id} [get]
+func GetCollection(c *fiber.Ctx) error {
+	// Catch collection ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		// Return status 500 and database connection error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Get collection by ID.
+	collection, err := db.GetCollection(id)
+	if err != nil {
+		// Return, if collection not found.
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":      true,
+			"msg":        "collection with the given ID is not found",
+			"collection": nil,
+		})
+	}
+
+	// A private collection is only visible to its owner; everyone else gets the same 404 a
+	// missing collection would, so existence of private collections isn't leaked either.
+	if !collection.IsPublic && !isCollectionOwner(c, collection) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":      true,
+			"msg":        "collection with the given ID is not found",
+			"collection": nil,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":      false,
+		"msg":        nil,
+		"collection": collection,
+	})
+}
+
+// GetCollectionBooks func gets the ordered member list of a collection.
+// @Description Get the ordered member list of a collection.
+// @Summary get the ordered member list of a collection
+// @Tags Public
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Success 200 {array} models.Book
+// @Router /api/v1/collection/{id}/books [get]
+func GetCollectionBooks(c *fiber.Ctx) error {
+	// Catch collection ID from URL.
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		// Return status 500 and database connection error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Checking, if collection with given ID is exists and is visible to the caller.
+	collection, err := db.GetCollection(id)
+	if err != nil || (!collection.IsPublic && !isCollectionOwner(c, collection)) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": true,
+			"msg":   "books were not found",
+			"count": 0,
+			"books": nil,
+		})
+	}
+
+	// Get the ordered member list.
+	books, err := db.GetCollectionBooks(id)
+	if err != nil {
+		// Return, if books not found.
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": true,
+			"msg":   "books were not found",
+			"count": 0,
+			"books": nil,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+		"count": len(books),
+		"books": books,
+	})
+}
+
+// CreateCollection func for creates a new collection.
+// @Description Create a new collection.
+// @Summary create a new collection
+// @Tags Private
+// @Accept json
+// @Produce json
+// @Param title body string true "Title"
+// @Success 201 {object} models.Collection
+// @Router /api/v1/collection [post]
+func CreateCollection(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 500 and JWT parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Set expiration time from JWT data of current collection.
+	expires := claims.Expires
+
+	// Set credential `collection:create` from JWT data of current collection.
+	credential := claims.Credentials["collection:create"]
+
+	// Create a new collection struct.
+	collection := &models.Collection{}
+
+	// Checking received data from JSON body.
+	if err := c.BodyParser(collection); err != nil {
+		// Return, if JSON data is not correct.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Only accounts with `collection:create` credential can create a new collection.
+	if credential && now < expires {
+		// Create a new validator for a collection model.
+		validate := validators.CollectionValidator()
+
+		// Validate collection fields.
+		if err := validate.Struct(collection); err != nil {
+			// Return, if some fields are not valid.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   utils.ValidatorErrors(err),
+			})
+		}
+
+		// Create database connection.
+		db, err := database.OpenDBConnection()
+		if err != nil {
+			// Return status 500 and database connection error.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+
+		// Set initialized default data for collection:
+		collection.ID = uuid.New()
+		collection.OwnerSub = claims.Subject
+		collection.CreatedAt = time.Now()
+		collection.UpdatedAt = time.Time{}
+
+		// Create a new collection with validated data.
+		if err := db.CreateCollection(collection); err != nil {
+			// Return status 500 and create collection process error.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+	} else {
+		// Return status 403 and permission denied error.
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":      true,
+			"msg":        "permission denied, check credentials or expiration time of your token",
+			"collection": nil,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error":      false,
+		"msg":        nil,
+		"collection": collection,
+	})
+}
+
+// UpdateCollection func for updates collection by given ID.
+// @Description Update collection.
+// @Summary update collection
+// @Tags Private
+// @Accept json
+// @Produce json
+// @Param id body string true "Collection ID"
+// @Success 202 {object} models.Collection
+// @Router /api/v1/collection [patch]
+func UpdateCollection(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 500 and JWT parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Set expiration time from JWT data of current collection.
+	expires := claims.Expires
+
+	// Set credential `collection:update` from JWT data of current collection.
+	credential := claims.Credentials["collection:update"]
+
+	// Create a new collection struct.
+	collection := &models.Collection{}
+
+	// Checking received data from JSON body.
+	if err := c.BodyParser(collection); err != nil {
+		// Return, if JSON data is not correct.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Only accounts with `collection:update` credential can update a collection.
+	if credential && now < expires {
+		// Create a new validator for a collection model.
+		validate := validators.CollectionValidator()
+
+		// Validate collection fields.
+		if err := validate.Struct(collection); err != nil {
+			// Return, if some fields are not valid.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   utils.ValidatorErrors(err),
+			})
+		}
+
+		// Create database connection.
+		db, err := database.OpenDBConnection()
+		if err != nil {
+			// Return status 500 and database connection error.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+
+		// Checking, if collection with given ID is exists.
+		existing, err := db.GetCollection(collection.ID)
+		if err != nil {
+			// Return status 404 and collection not found error.
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": true,
+				"msg":   "collection not found",
+			})
+		}
+
+		// Only the owning account may update its own collection.
+		if existing.OwnerSub != claims.Subject {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": true,
+				"msg":   "permission denied, you do not own this collection",
+			})
+		}
+
+		// Set collection data to update:
+		collection.UpdatedAt = time.Now()
+
+		// Update collection.
+		if err := db.UpdateCollection(collection); err != nil {
+			// Return status 500 and collection update error.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+	} else {
+		// Return status 403 and permission denied error.
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":      true,
+			"msg":        "permission denied, check credentials or expiration time of your token",
+			"collection": nil,
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"error":      false,
+		"msg":        nil,
+		"collection": collection,
+	})
+}
+
+// DeleteCollection func for deletes collection by given ID.
+// @Description Delete collection by given ID.
+// @Summary delete collection by given ID
+// @Tags Private
+// @Accept json
+// @Produce json
+// @Param id body string true "Collection ID"
+// @Success 200 {string} string "ok"
+// @Router /api/v1/collection [delete]
+func DeleteCollection(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 500 and JWT parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Set expiration time from JWT data of current collection.
+	expires := claims.Expires
+
+	// Set credential `collection:delete` from JWT data of current collection.
+	credential := claims.Credentials["collection:delete"]
+
+	// Create new Collection struct
+	collection := &models.Collection{}
+
+	// Check, if received JSON data is valid.
+	if err := c.BodyParser(collection); err != nil {
+		// Return status 500 and JSON parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Only accounts with `collection:delete` credential can delete a collection.
+	if credential && now < expires {
+		// Create database connection.
+		db, err := database.OpenDBConnection()
+		if err != nil {
+			// Return status 500 and database connection error.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+
+		// Checking, if collection with given ID is exists.
+		existing, err := db.GetCollection(collection.ID)
+		if err != nil {
+			// Return status 404 and collection not found error.
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": true,
+				"msg":   "collection not found",
+			})
+		}
+
+		// Only the owning account may delete its own collection.
+		if existing.OwnerSub != claims.Subject {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": true,
+				"msg":   "permission denied, you do not own this collection",
+			})
+		}
+
+		// Delete collection by given ID.
+		if err := db.DeleteCollection(collection.ID); err != nil {
+			// Return status 500 and delete collection process error.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": true,
+				"msg":   err.Error(),
+			})
+		}
+	} else {
+		// Return status 403 and permission denied error.
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": true,
+			"msg":   "permission denied, check credentials or expiration time of your token",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+	})
+}
+
+// AddBookToCollection func adds a book to the end of a collection's ordered member list.
+// @Description Add a book to a collection.
+// @Summary add a book to a collection
+// @Tags Private
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param book_id path string true "Book ID"
+// @Success 201 {string} string "ok"
+// @Router /api/v1/collection/{id}/book/{book_id} [post]
+func AddBookToCollection(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 500 and JWT parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Set expiration time from JWT data of current collection.
+	expires := claims.Expires
+
+	// Set credential `collection:update` from JWT data of current collection.
+	credential := claims.Credentials["collection:update"]
+
+	// Catch collection and book IDs from URL.
+	collectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	bookID, err := uuid.Parse(c.Params("book_id"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Only accounts with `collection:update` credential can manage collection members.
+	if !credential || now >= expires {
+		// Return status 403 and permission denied error.
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": true,
+			"msg":   "permission denied, check credentials or expiration time of your token",
+		})
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		// Return status 500 and database connection error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Checking, if collection with given ID is exists.
+	collection, err := db.GetCollection(collectionID)
+	if err != nil {
+		// Return status 404 and collection not found error.
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": true,
+			"msg":   "collection not found",
+		})
+	}
+
+	// Only the owning account may manage its own collection's members.
+	if collection.OwnerSub != claims.Subject {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": true,
+			"msg":   "permission denied, you do not own this collection",
+		})
+	}
+
+	// Add the book to the collection.
+	if err := db.AddBookToCollection(collectionID, bookID); err != nil {
+		// Return status 500 and add book to collection process error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+	})
+}
+
+// RemoveBookFromCollection func removes a book from a collection's member list.
+// @Description Remove a book from a collection.
+// @Summary remove a book from a collection
+// @Tags Private
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID"
+// @Param book_id path string true "Book ID"
+// @Success 200 {string} string "ok"
+// @Router /api/v1/collection/{id}/book/{book_id} [delete]
+func RemoveBookFromCollection(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 500 and JWT parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Set expiration time from JWT data of current collection.
+	expires := claims.Expires
+
+	// Set credential `collection:update` from JWT data of current collection.
+	credential := claims.Credentials["collection:update"]
+
+	// Catch collection and book IDs from URL.
+	collectionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	bookID, err := uuid.Parse(c.Params("book_id"))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Only accounts with `collection:update` credential can manage collection members.
+	if !credential || now >= expires {
+		// Return status 403 and permission denied error.
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": true,
+			"msg":   "permission denied, check credentials or expiration time of your token",
+		})
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		// Return status 500 and database connection error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Checking, if collection with given ID is exists.
+	collection, err := db.GetCollection(collectionID)
+	if err != nil {
+		// Return status 404 and collection not found error.
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": true,
+			"msg":   "collection not found",
+		})
+	}
+
+	// Only the owning account may manage its own collection's members.
+	if collection.OwnerSub != claims.Subject {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": true,
+			"msg":   "permission denied, you do not own this collection",
+		})
+	}
+
+	// Remove the book from the collection.
+	if err := db.RemoveBookFromCollection(collectionID, bookID); err != nil {
+		// Return status 500 and remove book from collection process error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error": false,
+		"msg":   nil,
+	})
+}
@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/koddr/tutorial-go-fiber-rest-api/pkg/utils"
+	"github.com/koddr/tutorial-go-fiber-rest-api/platform/database"
+)
+
+// GetEvents func gets the audit trail of book actions.
+// @Description Get the audit trail of book actions.
+// @Summary get the audit trail of book actions
+// @Tags Private
+// @Accept json
+// @Produce json
+// @Param take query string false "Take"
+// @Param offset query string false "Offset"
+// @Param action query string false "Action"
+// @Param target_id query string false "Target ID"
+// @Success 200 {array} models.ActionEvent
+// @Router /api/v1/events [get]
+func GetEvents(c *fiber.Ctx) error {
+	// Get now time.
+	now := time.Now().Unix()
+
+	// Get claims from JWT.
+	claims, err := utils.ExtractTokenMetadata(c)
+	if err != nil {
+		// Return status 500 and JWT parse error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Set expiration time from JWT data of current account.
+	expires := claims.Expires
+
+	// Set credential `events:read` from JWT data of current account.
+	credential := claims.Credentials["events:read"]
+
+	// Only accounts with `events:read` credential can read the audit trail.
+	if !credential || now >= expires {
+		// Return status 403 and permission denied error.
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": true,
+			"msg":   "permission denied, check credentials or expiration time of your token",
+		})
+	}
+
+	opts, err := database.NewActionEventListOptions(
+		c.Query("take"), c.Query("offset"), c.Query("action"), c.Query("target_id"),
+	)
+	if err != nil {
+		// Return status 400 and the invalid query parameter error.
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Create database connection.
+	db, err := database.OpenDBConnection()
+	if err != nil {
+		// Return status 500 and database connection error.
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": true,
+			"msg":   err.Error(),
+		})
+	}
+
+	// Get audit events matching opts.
+	events, err := db.GetActionEvents(opts)
+	if err != nil {
+		// Return, if events not found.
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":  true,
+			"msg":    "events were not found",
+			"count":  0,
+			"events": nil,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"error":  false,
+		"msg":    nil,
+		"count":  len(events),
+		"events": events,
+	})
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActionEvent struct to describe a single audited action taken against a resource.
+type ActionEvent struct {
+	ID         uuid.UUID       `db:"id" json:"id"`
+	AccountSub string          `db:"account_sub" json:"account_sub"`
+	Action     string          `db:"action" json:"action"`
+	TargetType string          `db:"target_type" json:"target_type"`
+	TargetID   uuid.UUID       `db:"target_id" json:"target_id"`
+	IP         string          `db:"ip" json:"ip"`
+	UserAgent  string          `db:"user_agent" json:"user_agent"`
+	Metadata   json.RawMessage `db:"metadata" json:"metadata,omitempty"`
+	CreatedAt  time.Time       `db:"created_at" json:"created_at"`
+}
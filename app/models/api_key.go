@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ApiKey struct to describe an API key issued to an account as an alternative to a JWT.
+type ApiKey struct {
+	ID           uuid.UUID      `db:"id" json:"id"`
+	OwnerSub     string         `db:"owner_sub" json:"owner_sub"`
+	Name         string         `db:"name" json:"name" validate:"required,min=2,max=50"`
+	HashedSecret string         `db:"hashed_secret" json:"-"`
+	Scopes       pq.StringArray `db:"scopes" json:"scopes"`
+	ExpiresAt    *time.Time     `db:"expires_at" json:"expires_at,omitempty"`
+	RevokedAt    *time.Time     `db:"revoked_at" json:"revoked_at,omitempty"`
+	LastUsedAt   *time.Time     `db:"last_used_at" json:"last_used_at,omitempty"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+}
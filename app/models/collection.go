@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Collection struct to describe a named, ordered grouping of books owned by an account.
+type Collection struct {
+	ID          uuid.UUID `db:"id" json:"id"`
+	OwnerSub    string    `db:"owner_sub" json:"owner_sub"`
+	Title       string    `db:"title" json:"title" validate:"required,min=4,max=100"`
+	Description string    `db:"description" json:"description" validate:"max=500"`
+	IsPublic    bool      `db:"is_public" json:"is_public"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,10 @@
+package validators
+
+import "github.com/go-playground/validator/v10"
+
+// ApiKeyValidator func for create a new validator for model fields of an API key.
+func ApiKeyValidator() *validator.Validate {
+	validate := validator.New()
+
+	return validate
+}
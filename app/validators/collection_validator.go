@@ -0,0 +1,10 @@
+package validators
+
+import "github.com/go-playground/validator/v10"
+
+// CollectionValidator func for create a new validator for model fields of a collection.
+func CollectionValidator() *validator.Validate {
+	validate := validator.New()
+
+	return validate
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// apiKeySecretPrefix marks a bearer value as an API key rather than a JWT.
+const apiKeySecretPrefix = "sk_"
+
+// GenerateAPIKeySecret func creates a new random API key secret, returning the plaintext value
+// (to be shown to the caller exactly once) together with its ID and salted hash for storage.
+func GenerateAPIKeySecret() (plaintext string, id uuid.UUID, hashedSecret string, err error) {
+	id = uuid.New()
+
+	raw := make([]byte, 32)
+	if _, err = rand.Read(raw); err != nil {
+		return "", uuid.Nil, "", err
+	}
+	random := base64.RawURLEncoding.EncodeToString(raw)
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(random), bcrypt.DefaultCost)
+	if err != nil {
+		return "", uuid.Nil, "", err
+	}
+
+	plaintext = fmt.Sprintf("%s%s.%s", apiKeySecretPrefix, id, random)
+
+	return plaintext, id, string(hashed), nil
+}
+
+// ParseAPIKeySecret func splits a `sk_<id>.<secret>` value into its key ID and secret.
+func ParseAPIKeySecret(value string) (uuid.UUID, string, error) {
+	value = strings.TrimPrefix(value, apiKeySecretPrefix)
+
+	parts := strings.SplitN(value, ".", 2)
+	if len(parts) != 2 {
+		return uuid.Nil, "", fmt.Errorf("malformed api key")
+	}
+
+	id, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("malformed api key")
+	}
+
+	return id, parts[1], nil
+}
+
+// CompareAPIKeySecret func checks secret against its stored salted hash.
+func CompareAPIKeySecret(hashedSecret, secret string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hashedSecret), []byte(secret))
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ConditionalCache func sets the ETag and Last-Modified response headers for lastEdit/etag
+// and, if the request's If-None-Match or If-Modified-Since header already matches, writes a
+// 304 Not Modified response and returns true so the caller can skip its own work.
+func ConditionalCache(c *fiber.Ctx, lastEdit time.Time, etag string) bool {
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, lastEdit.UTC().Format(http.TimeFormat))
+
+	if match := c.Get(fiber.HeaderIfNoneMatch); match != "" && match == etag {
+		c.Status(fiber.StatusNotModified)
+		return true
+	}
+
+	if since := c.Get(fiber.HeaderIfModifiedSince); since != "" {
+		// HTTP dates carry whole seconds only, so compare at that resolution.
+		if t, err := http.ParseTime(since); err == nil && !lastEdit.Truncate(time.Second).After(t) {
+			c.Status(fiber.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
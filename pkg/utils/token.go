@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// TokenMetadata struct to describe metadata in JWT, or the claims synthesized by the API key
+// middleware for a request authenticated with `Authorization: Bearer sk_...` or `X-API-Key`.
+type TokenMetadata struct {
+	Subject     string
+	Credentials map[string]bool
+	Expires     int64
+}
+
+// ExtractTokenMetadata func extracts metadata from the request's JWT, or from the claims an
+// upstream API key middleware has already synthesized and stored in c.Locals.
+func ExtractTokenMetadata(c *fiber.Ctx) (*TokenMetadata, error) {
+	if claims, ok := c.Locals("apiKeyClaims").(*TokenMetadata); ok {
+		return claims, nil
+	}
+
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return nil, fmt.Errorf("missing or invalid token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("missing or invalid token claims")
+	}
+
+	expires, _ := claims["exp"].(float64)
+	subject, _ := claims["sub"].(string)
+
+	credentials := make(map[string]bool)
+	if raw, ok := claims["credentials"].(map[string]interface{}); ok {
+		for scope, allowed := range raw {
+			if b, ok := allowed.(bool); ok {
+				credentials[scope] = b
+			}
+		}
+	}
+
+	return &TokenMetadata{
+		Subject:     subject,
+		Credentials: credentials,
+		Expires:     int64(expires),
+	}, nil
+}
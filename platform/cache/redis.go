@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// defaultBooksCacheTTL is used when BOOKS_CACHE_TTL is not set or invalid.
+const defaultBooksCacheTTL = 30 * time.Second
+
+// ctx is the background context used for all Redis commands issued by this package.
+var ctx = context.Background()
+
+// redisClientOnce memoizes the Redis client (and any error constructing it) so
+// OpenRedisConnection never opens a second connection pool per process; without this, every
+// request would leak its own unclosed client.
+var (
+	redisClientOnce sync.Once
+	redisClient     *redis.Client
+	redisClientErr  error
+)
+
+// OpenRedisConnection func for opening redis connection.
+func OpenRedisConnection() (*redis.Client, error) {
+	redisClientOnce.Do(func() {
+		// Parse Redis DB index from .env file.
+		db, err := strconv.Atoi(os.Getenv("REDIS_DB"))
+		if err != nil {
+			db = 0
+		}
+
+		// Define Redis connection options.
+		client := redis.NewClient(&redis.Options{
+			Addr:     os.Getenv("REDIS_ADDR"),
+			Password: os.Getenv("REDIS_PASSWORD"),
+			DB:       db,
+		})
+
+		// Try to ping Redis to check, if it's alive.
+		if err := client.Ping(ctx).Err(); err != nil {
+			redisClientErr = err
+			return
+		}
+
+		redisClient = client
+	})
+
+	return redisClient, redisClientErr
+}
+
+// BooksCacheTTL func returns configured TTL for cached book payloads from BOOKS_CACHE_TTL
+// (in seconds), falling back to a 30 second default.
+func BooksCacheTTL() time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv("BOOKS_CACHE_TTL"))
+	if err != nil || seconds <= 0 {
+		return defaultBooksCacheTTL
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// GetCache func gets a cached payload by key, returning ok == false on a miss or when
+// Redis itself is unreachable so callers can fall through to the database.
+func GetCache(rdb *redis.Client, key string) (value []byte, ok bool) {
+	value, err := rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	return value, true
+}
+
+// SetCache func stores a payload under key with the given TTL, ignoring errors so that a
+// degraded Redis never affects the HTTP response.
+func SetCache(rdb *redis.Client, key string, value []byte, ttl time.Duration) {
+	_ = rdb.Set(ctx, key, value, ttl).Err()
+}
+
+// InvalidateCache func atomically deletes one or more keys, ignoring errors so that a
+// degraded Redis never affects the HTTP response.
+func InvalidateCache(rdb *redis.Client, keys ...string) {
+	_ = rdb.Del(ctx, keys...).Err()
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/koddr/tutorial-go-fiber-rest-api/app/models"
+)
+
+// ActionEventListOptions carries the validated pagination and filtering parameters for
+// GetActionEvents.
+type ActionEventListOptions struct {
+	Take     int
+	Offset   int
+	Action   string
+	TargetID *uuid.UUID
+}
+
+// NewActionEventListOptions func builds ActionEventListOptions from the raw `take`, `offset`,
+// `action`, and `target_id` query parameters, rejecting a take outside [1, 100].
+func NewActionEventListOptions(take, offset, action, targetID string) (ActionEventListOptions, error) {
+	opts := ActionEventListOptions{Take: 20, Action: action}
+
+	if take != "" {
+		n, err := strconv.Atoi(take)
+		if err != nil || n < 1 || n > 100 {
+			return opts, fmt.Errorf("take must be an integer between 1 and 100")
+		}
+		opts.Take = n
+	}
+
+	if offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			return opts, fmt.Errorf("offset must be a non-negative integer")
+		}
+		opts.Offset = n
+	}
+
+	if targetID != "" {
+		id, err := uuid.Parse(targetID)
+		if err != nil {
+			return opts, fmt.Errorf("target_id must be a valid UUID")
+		}
+		opts.TargetID = &id
+	}
+
+	return opts, nil
+}
+
+// whereClause builds the WHERE clause and bind args for the configured filters.
+func (o ActionEventListOptions) whereClause() (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if o.Action != "" {
+		clauses = append(clauses, "action = ?")
+		args = append(args, o.Action)
+	}
+
+	if o.TargetID != nil {
+		clauses = append(clauses, "target_id = ?")
+		args = append(args, *o.TargetID)
+	}
+
+	if len(clauses) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// CreateActionEvent func records a single audit row; called after a mutation's DB write
+// succeeds.
+func (q *Queries) CreateActionEvent(event *models.ActionEvent) error {
+	_, err := q.NamedExec(`
+		INSERT INTO action_events (id, account_sub, action, target_type, target_id, ip, user_agent, metadata, created_at)
+		VALUES (:id, :account_sub, :action, :target_type, :target_id, :ip, :user_agent, :metadata, :created_at)
+	`, event)
+
+	return err
+}
+
+// GetActionEvents func returns the audit trail page matching opts, most recent first.
+func (q *Queries) GetActionEvents(opts ActionEventListOptions) ([]*models.ActionEvent, error) {
+	where, args := opts.whereClause()
+
+	query := fmt.Sprintf(
+		"SELECT * FROM action_events %s ORDER BY created_at DESC LIMIT ? OFFSET ?",
+		where,
+	)
+
+	var events []*models.ActionEvent
+	if err := q.Select(&events, q.Rebind(query), append(args, opts.Take, opts.Offset)...); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/koddr/tutorial-go-fiber-rest-api/app/models"
+)
+
+// CreateApiKey func for creates a new API key.
+func (q *Queries) CreateApiKey(key *models.ApiKey) error {
+	_, err := q.NamedExec(`
+		INSERT INTO api_keys (id, owner_sub, name, hashed_secret, scopes, expires_at, revoked_at, last_used_at, created_at)
+		VALUES (:id, :owner_sub, :name, :hashed_secret, :scopes, :expires_at, :revoked_at, :last_used_at, :created_at)
+	`, key)
+
+	return err
+}
+
+// GetApiKeysByOwner func lists the API keys owned by ownerSub, most recent first.
+func (q *Queries) GetApiKeysByOwner(ownerSub string) ([]*models.ApiKey, error) {
+	var keys []*models.ApiKey
+
+	err := q.Select(&keys, "SELECT * FROM api_keys WHERE owner_sub = $1 ORDER BY created_at DESC", ownerSub)
+
+	return keys, err
+}
+
+// GetApiKeyByID func gets an API key by its ID, regardless of owner, for auth lookups.
+func (q *Queries) GetApiKeyByID(id uuid.UUID) (*models.ApiKey, error) {
+	key := &models.ApiKey{}
+
+	err := q.Get(key, "SELECT * FROM api_keys WHERE id = $1", id)
+
+	return key, err
+}
+
+// RevokeApiKey func marks an API key owned by ownerSub as revoked.
+func (q *Queries) RevokeApiKey(id uuid.UUID, ownerSub string) error {
+	_, err := q.Exec(
+		"UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND owner_sub = $3 AND revoked_at IS NULL",
+		time.Now(), id, ownerSub,
+	)
+
+	return err
+}
+
+// TouchApiKeyLastUsed func bumps last_used_at for id.
+func (q *Queries) TouchApiKeyLastUsed(id uuid.UUID) error {
+	_, err := q.Exec("UPDATE api_keys SET last_used_at = $1 WHERE id = $2", time.Now(), id)
+
+	return err
+}
@@ -0,0 +1,149 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/koddr/tutorial-go-fiber-rest-api/app/models"
+)
+
+// allowedBookSortColumns whitelists the columns GetBooksPaged may sort by, so a caller can
+// never smuggle arbitrary SQL in via the `sort` query parameter.
+var allowedBookSortColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"title":      true,
+	"author":     true,
+}
+
+// BookListOptions carries the validated pagination, filtering, and sorting parameters for
+// GetBooksPaged.
+type BookListOptions struct {
+	Take       int
+	Offset     int
+	SortColumn string
+	SortDesc   bool
+	Author     string
+	Status     *int
+	Query      string
+}
+
+// NewBookListOptions func builds BookListOptions from the raw `take`, `offset`, `sort`,
+// `author`, `status`, and `q` query parameters, rejecting a take outside [1, 100] and
+// unknown sort columns.
+func NewBookListOptions(take, offset, sort, author, status, q string) (BookListOptions, error) {
+	opts := BookListOptions{
+		Take:       20,
+		SortColumn: "created_at",
+		SortDesc:   true,
+		Author:     author,
+		Query:      q,
+	}
+
+	if take != "" {
+		n, err := strconv.Atoi(take)
+		if err != nil || n < 1 || n > 100 {
+			return opts, fmt.Errorf("take must be an integer between 1 and 100")
+		}
+		opts.Take = n
+	}
+
+	if offset != "" {
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 {
+			return opts, fmt.Errorf("offset must be a non-negative integer")
+		}
+		opts.Offset = n
+	}
+
+	if sort != "" {
+		column, direction := sort, "asc"
+		if idx := strings.LastIndex(sort, "."); idx != -1 {
+			column, direction = sort[:idx], sort[idx+1:]
+		}
+		if direction != "asc" && direction != "desc" {
+			return opts, fmt.Errorf("sort direction must be asc or desc")
+		}
+		if !allowedBookSortColumns[column] {
+			return opts, fmt.Errorf("sort column %q is not allowed", column)
+		}
+		opts.SortColumn = column
+		opts.SortDesc = direction == "desc"
+	}
+
+	if status != "" {
+		n, err := strconv.Atoi(status)
+		if err != nil {
+			return opts, fmt.Errorf("status must be an integer")
+		}
+		opts.Status = &n
+	}
+
+	return opts, nil
+}
+
+// whereClause builds the WHERE clause and bind args for the configured filters.
+func (o BookListOptions) whereClause() (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if o.Author != "" {
+		clauses = append(clauses, "author = ?")
+		args = append(args, o.Author)
+	}
+
+	if o.Status != nil {
+		clauses = append(clauses, "book_status = ?")
+		args = append(args, *o.Status)
+	}
+
+	if o.Query != "" {
+		clauses = append(clauses, "title ILIKE ?")
+		args = append(args, "%"+o.Query+"%")
+	}
+
+	if len(clauses) == 0 {
+		return "", args
+	}
+
+	return "WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// GetBooksPaged func returns the page of books matching opts together with the total match
+// count (ignoring take/offset), issuing a COUNT(*) and the paged SELECT in one transaction.
+func (q *Queries) GetBooksPaged(opts BookListOptions) ([]*models.Book, int, error) {
+	tx, err := q.Beginx()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer tx.Rollback()
+
+	where, args := opts.whereClause()
+
+	var total int
+	if err := tx.Get(&total, tx.Rebind(fmt.Sprintf("SELECT COUNT(*) FROM books %s", where)), args...); err != nil {
+		return nil, 0, err
+	}
+
+	direction := "ASC"
+	if opts.SortDesc {
+		direction = "DESC"
+	}
+
+	query := fmt.Sprintf(
+		"SELECT * FROM books %s ORDER BY %s %s LIMIT ? OFFSET ?",
+		where, opts.SortColumn, direction,
+	)
+
+	var books []*models.Book
+	if err := tx.Select(&books, tx.Rebind(query), append(args, opts.Take, opts.Offset)...); err != nil {
+		return nil, 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, err
+	}
+
+	return books, total, nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"github.com/google/uuid"
+	"github.com/koddr/tutorial-go-fiber-rest-api/app/models"
+)
+
+// CreateCollection func for creates a new collection.
+func (q *Queries) CreateCollection(collection *models.Collection) error {
+	_, err := q.NamedExec(`
+		INSERT INTO collections (id, owner_sub, title, description, is_public, created_at, updated_at)
+		VALUES (:id, :owner_sub, :title, :description, :is_public, :created_at, :updated_at)
+	`, collection)
+
+	return err
+}
+
+// GetCollection func gets collection by given ID.
+func (q *Queries) GetCollection(id uuid.UUID) (*models.Collection, error) {
+	collection := &models.Collection{}
+
+	err := q.Get(collection, "SELECT * FROM collections WHERE id = $1", id)
+
+	return collection, err
+}
+
+// UpdateCollection func for updates collection by given ID.
+func (q *Queries) UpdateCollection(collection *models.Collection) error {
+	_, err := q.NamedExec(`
+		UPDATE collections SET title = :title, description = :description, is_public = :is_public, updated_at = :updated_at
+		WHERE id = :id
+	`, collection)
+
+	return err
+}
+
+// DeleteCollection func for deletes collection by given ID.
+func (q *Queries) DeleteCollection(id uuid.UUID) error {
+	_, err := q.Exec("DELETE FROM collections WHERE id = $1", id)
+
+	return err
+}
+
+// AddBookToCollection func appends bookID to the end of collectionID's ordered member list.
+func (q *Queries) AddBookToCollection(collectionID, bookID uuid.UUID) error {
+	_, err := q.Exec(`
+		INSERT INTO collection_books (collection_id, book_id, position)
+		VALUES ($1, $2, (SELECT COALESCE(MAX(position), 0) + 1 FROM collection_books WHERE collection_id = $1))
+		ON CONFLICT (collection_id, book_id) DO NOTHING
+	`, collectionID, bookID)
+
+	return err
+}
+
+// RemoveBookFromCollection func removes bookID from collectionID's member list.
+func (q *Queries) RemoveBookFromCollection(collectionID, bookID uuid.UUID) error {
+	_, err := q.Exec(
+		"DELETE FROM collection_books WHERE collection_id = $1 AND book_id = $2",
+		collectionID, bookID,
+	)
+
+	return err
+}
+
+// GetCollectionBooks func returns the books of collectionID in member order.
+func (q *Queries) GetCollectionBooks(collectionID uuid.UUID) ([]*models.Book, error) {
+	var books []*models.Book
+
+	err := q.Select(&books, `
+		SELECT books.* FROM books
+		JOIN collection_books ON collection_books.book_id = books.id
+		WHERE collection_books.collection_id = $1
+		ORDER BY collection_books.position ASC
+	`, collectionID)
+
+	return books, err
+}
+
+// GetBookCollections func returns the collections bookID belongs to, for `?expand=collections`.
+func (q *Queries) GetBookCollections(bookID uuid.UUID) ([]*models.Collection, error) {
+	var collections []*models.Collection
+
+	err := q.Select(&collections, `
+		SELECT collections.* FROM collections
+		JOIN collection_books ON collection_books.collection_id = collections.id
+		WHERE collection_books.book_id = $1
+		ORDER BY collections.created_at ASC
+	`, bookID)
+
+	return collections, err
+}
@@ -0,0 +1,9 @@
+package database
+
+import "github.com/jmoiron/sqlx"
+
+// Queries wraps the SQL connection pool and exposes the data-access methods used by the
+// controllers.
+type Queries struct {
+	*sqlx.DB
+}
@@ -0,0 +1,29 @@
+package events
+
+import "errors"
+
+// ChannelPublisher is an in-process Publisher backed by a buffered channel, used in tests and
+// selected by EVENTS_PUBLISHER=channel.
+type ChannelPublisher struct {
+	events chan Event
+}
+
+// NewChannelPublisher func creates a ChannelPublisher with the given buffer size.
+func NewChannelPublisher(buffer int) *ChannelPublisher {
+	return &ChannelPublisher{events: make(chan Event, buffer)}
+}
+
+// Publish func sends event onto the channel, returning an error if the buffer is full.
+func (p *ChannelPublisher) Publish(event Event) error {
+	select {
+	case p.events <- event:
+		return nil
+	default:
+		return errors.New("events: channel publisher buffer is full")
+	}
+}
+
+// Events func exposes the channel for subscribers, e.g. tests asserting on published events.
+func (p *ChannelPublisher) Events() <-chan Event {
+	return p.events
+}
@@ -0,0 +1,19 @@
+package events
+
+import "time"
+
+// Event describes a single mutation to a domain object for outbound consumers.
+type Event struct {
+	Object        string      `json:"object"`
+	Action        string      `json:"action"`
+	Data          interface{} `json:"data"`
+	Actor         string      `json:"actor"`
+	RequestSource string      `json:"request_source"`
+	Timestamp     time.Time   `json:"timestamp"`
+}
+
+// Publisher is implemented by every event transport this package supports.
+type Publisher interface {
+	// Publish sends event to the transport, returning an error if it could not be delivered.
+	Publish(event Event) error
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NatsPublisher is a Publisher that broadcasts events over a NATS subject.
+type NatsPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// NewNatsPublisher func creates a NatsPublisher from the NATS_URL and EVENTS_NATS_SUBJECT env
+// vars.
+func NewNatsPublisher() (*NatsPublisher, error) {
+	conn, err := nats.Connect(os.Getenv("NATS_URL"))
+	if err != nil {
+		return nil, err
+	}
+
+	subject := os.Getenv("EVENTS_NATS_SUBJECT")
+	if subject == "" {
+		subject = "events"
+	}
+
+	return &NatsPublisher{conn: conn, subject: subject}, nil
+}
+
+// Publish func marshals event to JSON and publishes it on the configured subject.
+func (p *NatsPublisher) Publish(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return p.conn.Publish(p.subject, payload)
+}
@@ -0,0 +1,11 @@
+package events
+
+// NoopPublisher is a Publisher that discards every event; it backs the unconfigured
+// (EVENTS_PUBLISHER unset) deployment so mutation endpoints never pile up "buffer full"
+// errors when nothing is actually meant to consume events.
+type NoopPublisher struct{}
+
+// Publish func discards event and always succeeds.
+func (NoopPublisher) Publish(event Event) error {
+	return nil
+}
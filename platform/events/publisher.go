@@ -0,0 +1,51 @@
+package events
+
+import (
+	"os"
+	"sync"
+)
+
+// defaultChannelPublisher backs the explicit "channel" transport so tests can observe
+// published events without any external dependency.
+var defaultChannelPublisher = NewChannelPublisher(100)
+
+// redisPublisherOnce and natsPublisherOnce memoize their respective publisher (and any error
+// constructing it) so NewPublisher never opens a second Redis/NATS connection per process;
+// without this, every mutation request would leak its own unclosed connection.
+var (
+	redisPublisherOnce sync.Once
+	redisPublisher     Publisher
+	redisPublisherErr  error
+
+	natsPublisherOnce sync.Once
+	natsPublisher     Publisher
+	natsPublisherErr  error
+)
+
+// NewPublisher func returns the Publisher selected by the EVENTS_PUBLISHER env var
+// ("redis", "nats", or "channel"), defaulting to a no-op publisher when unset so an
+// unconfigured deployment never pays for a transport nothing consumes.
+func NewPublisher() (Publisher, error) {
+	switch os.Getenv("EVENTS_PUBLISHER") {
+	case "redis":
+		redisPublisherOnce.Do(func() {
+			redisPublisher, redisPublisherErr = NewRedisPublisher()
+		})
+		return redisPublisher, redisPublisherErr
+	case "nats":
+		natsPublisherOnce.Do(func() {
+			natsPublisher, natsPublisherErr = NewNatsPublisher()
+		})
+		return natsPublisher, natsPublisherErr
+	case "channel":
+		return defaultChannelPublisher, nil
+	default:
+		return NoopPublisher{}, nil
+	}
+}
+
+// DefaultChannelPublisher func returns the package-level in-process publisher used by the
+// explicit "channel" transport, mainly useful for tests asserting on published events.
+func DefaultChannelPublisher() *ChannelPublisher {
+	return defaultChannelPublisher
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisPublisher is a Publisher that broadcasts events over a Redis Pub/Sub channel.
+type RedisPublisher struct {
+	client  *redis.Client
+	channel string
+}
+
+// NewRedisPublisher func creates a RedisPublisher from EVENTS_REDIS_ADDR, EVENTS_REDIS_PASSWORD,
+// EVENTS_REDIS_DB and EVENTS_REDIS_CHANNEL env vars.
+func NewRedisPublisher() (*RedisPublisher, error) {
+	db, err := strconv.Atoi(os.Getenv("EVENTS_REDIS_DB"))
+	if err != nil {
+		db = 0
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     os.Getenv("EVENTS_REDIS_ADDR"),
+		Password: os.Getenv("EVENTS_REDIS_PASSWORD"),
+		DB:       db,
+	})
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, err
+	}
+
+	channel := os.Getenv("EVENTS_REDIS_CHANNEL")
+	if channel == "" {
+		channel = "events"
+	}
+
+	return &RedisPublisher{client: client, channel: channel}, nil
+}
+
+// Publish func marshals event to JSON and publishes it on the configured channel.
+func (p *RedisPublisher) Publish(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return p.client.Publish(context.Background(), p.channel, payload).Err()
+}